@@ -0,0 +1,22 @@
+package smchash
+
+// bulkBlock folds one blockSize-byte block at p into the running
+// bulk-lane state see, using secret for the lane constants. It is the
+// hot loop body of HashSeeded/HashSecret's length>128 path and of
+// Digest's streaming equivalent.
+//
+// Architectures with an accelerated implementation swap this var in an
+// init function (see hash_amd64.go, hash_arm64.go); every implementation
+// must be bit-identical to bulkBlockGeneric.
+var bulkBlock = bulkBlockGeneric
+
+func bulkBlockGeneric(p []byte, secret *[9]uint64, see *[8]uint64) {
+	see[0] = mix(read64(p)^secret[0], read64(p[8:])^see[0])
+	see[1] = mix(read64(p[16:])^secret[1], read64(p[24:])^see[1])
+	see[2] = mix(read64(p[32:])^secret[2], read64(p[40:])^see[2])
+	see[3] = mix(read64(p[48:])^secret[3], read64(p[56:])^see[3])
+	see[4] = mix(read64(p[64:])^secret[4], read64(p[72:])^see[4])
+	see[5] = mix(read64(p[80:])^secret[5], read64(p[88:])^see[5])
+	see[6] = mix(read64(p[96:])^secret[6], read64(p[104:])^see[6])
+	see[7] = mix(read64(p[112:])^secret[7], read64(p[120:])^see[7])
+}
@@ -0,0 +1,34 @@
+//go:build amd64 && gc && !purego
+
+package smchash
+
+// cpuid and xgetbv are implemented in cpu_amd64.s.
+
+//go:noescape
+func cpuid(eax, ecx uint32) (a, b, c, d uint32)
+
+//go:noescape
+func xgetbv() (eax, edx uint32)
+
+// hasAVX2BMI2 reports whether the CPU (and OS, via XGETBV) supports
+// both AVX2 and BMI2, the prerequisites for bulkBlockAVX2.
+var hasAVX2BMI2 = detectAVX2BMI2()
+
+func detectAVX2BMI2() bool {
+	_, _, c1, _ := cpuid(1, 0)
+	osSavesYMM := c1&(1<<27) != 0 // OSXSAVE
+	hasAVX := c1&(1<<28) != 0
+	if !osSavesYMM || !hasAVX {
+		return false
+	}
+
+	xcr0, _ := xgetbv()
+	if xcr0&0x6 != 0x6 { // XMM and YMM state both enabled
+		return false
+	}
+
+	_, ebx7, _, _ := cpuid(7, 0)
+	hasAVX2 := ebx7&(1<<5) != 0
+	hasBMI2 := ebx7&(1<<8) != 0
+	return hasAVX2 && hasBMI2
+}
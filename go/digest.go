@@ -0,0 +1,206 @@
+package smchash
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+)
+
+// blockSize is the bulk-lane block width used by HashSeeded/HashSecret;
+// Digest buffers up to this many bytes before folding them into the
+// running bulk state.
+const blockSize = 128
+
+// Seed is an opaque smcHash seed, analogous to hash/maphash.Seed. It
+// exists so New can auto-seed a Digest without callers needing to
+// manage a uint64 themselves.
+type Seed struct {
+	s uint64
+}
+
+// MakeSeed returns a random Seed derived from crypto/rand. Two calls to
+// MakeSeed return different seeds (with overwhelming probability), so
+// Digests seeded from separate calls produce uncorrelated hashes.
+func MakeSeed() Seed {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic("smchash: crypto/rand unavailable: " + err.Error())
+	}
+	return Seed{s: binary.LittleEndian.Uint64(b[:])}
+}
+
+// Digest implements hash.Hash64 (and io.Writer) for smcHash, letting
+// callers feed data incrementally instead of assembling the whole
+// []byte up front. The zero value is not ready for use; construct one
+// with New or NewWithSeed.
+//
+// Sum64 on a fully-written Digest always equals
+// HashSeeded(concatenation of all written bytes, seed).
+type Digest struct {
+	seed uint64
+
+	bulk bool
+	see  [8]uint64
+
+	buf    [blockSize]byte
+	buflen int
+
+	tail []byte // most recent (up to) 16 bytes written, for finalization
+
+	length uint64
+}
+
+// New creates a Digest seeded via MakeSeed, so independent Digests in
+// the same process produce uncorrelated hashes.
+func New() *Digest {
+	d := new(Digest)
+	d.SetSeed(MakeSeed().s)
+	return d
+}
+
+// NewWithSeed creates a Digest using the given explicit seed. Its Sum64
+// reproduces HashSeeded(data, seed) for the bytes written.
+func NewWithSeed(seed uint64) *Digest {
+	d := new(Digest)
+	d.SetSeed(seed)
+	return d
+}
+
+// SetSeed changes the seed used by the Digest and resets it, discarding
+// any bytes written so far.
+func (d *Digest) SetSeed(seed uint64) {
+	d.seed = seed
+	d.Reset()
+}
+
+// Seed returns the seed currently in use.
+func (d *Digest) Seed() uint64 {
+	return d.seed
+}
+
+// Reset discards any bytes written so far, without changing the seed.
+func (d *Digest) Reset() {
+	d.bulk = false
+	d.see = [8]uint64{}
+	d.buflen = 0
+	d.tail = d.tail[:0]
+	d.length = 0
+}
+
+// Size returns the number of bytes Sum appends: 8.
+func (d *Digest) Size() int { return 8 }
+
+// BlockSize returns 1: Digest has no preferred write alignment.
+func (d *Digest) BlockSize() int { return 1 }
+
+// Write adds more data to the running hash. It never returns an error.
+func (d *Digest) Write(p []byte) (int, error) {
+	n := len(p)
+	d.length += uint64(n)
+	d.updateTail(p)
+
+	for len(p) > 0 {
+		if d.buflen < blockSize {
+			c := copy(d.buf[d.buflen:], p)
+			d.buflen += c
+			p = p[c:]
+		}
+		if d.buflen == blockSize && len(p) > 0 {
+			d.foldBlock()
+			d.buflen = 0
+			continue
+		}
+		break
+	}
+	return n, nil
+}
+
+// WriteString adds more data to the running hash, equivalent to
+// Write([]byte(s)) but without requiring the caller to convert first.
+func (d *Digest) WriteString(s string) (int, error) {
+	return d.Write([]byte(s))
+}
+
+// WriteByte adds a single byte to the running hash. It never returns an
+// error.
+func (d *Digest) WriteByte(c byte) error {
+	var b [1]byte
+	b[0] = c
+	_, _ = d.Write(b[:])
+	return nil
+}
+
+// updateTail keeps d.tail equal to the last (up to) 16 bytes written so
+// far, independent of block folding, since HashSeeded's finalization
+// always reads from the tail of the whole input.
+func (d *Digest) updateTail(p []byte) {
+	if len(p) >= 16 {
+		d.tail = append(d.tail[:0], p[len(p)-16:]...)
+		return
+	}
+	d.tail = append(d.tail, p...)
+	if len(d.tail) > 16 {
+		d.tail = d.tail[len(d.tail)-16:]
+	}
+}
+
+// foldBlock mixes a full blockSize-byte block in d.buf into the running
+// bulk-lane state, mirroring the loop body in HashSeeded's length>128
+// path. It is only ever called on a block known not to be the final one.
+func (d *Digest) foldBlock() {
+	if !d.bulk {
+		s := d.seed ^ mix(d.seed^smcSecret[2], smcSecret[1])
+		d.see = [8]uint64{s, s, s, s, s, s, s, s}
+		d.bulk = true
+	}
+	bulkBlock(d.buf[:blockSize], &smcSecret, &d.see)
+}
+
+// Sum64 returns the smcHash of all bytes written so far.
+func (d *Digest) Sum64() uint64 {
+	if !d.bulk {
+		// Nothing has overflowed a single block yet, so buf holds the
+		// entire input: defer straight to the non-streaming function.
+		return HashSeeded(d.buf[:d.buflen], d.seed)
+	}
+
+	seed := d.see[0] ^ d.see[1] ^ d.see[4] ^ d.see[5]
+	see2 := d.see[2] ^ d.see[3] ^ d.see[6] ^ d.see[7]
+	seed ^= see2
+
+	i := d.buflen
+	p := d.buf[:i]
+	if i > 64 {
+		seed = mix(read64(p)^smcSecret[0], read64(p[8:])^seed)
+		seed = mix(read64(p[16:])^smcSecret[1], read64(p[24:])^seed)
+		seed = mix(read64(p[32:])^smcSecret[2], read64(p[40:])^seed)
+		seed = mix(read64(p[48:])^smcSecret[3], read64(p[56:])^seed)
+		p = p[64:]
+		i -= 64
+	}
+	if i > 32 {
+		seed = mix(read64(p)^smcSecret[0], read64(p[8:])^seed)
+		seed = mix(read64(p[16:])^smcSecret[1], read64(p[24:])^seed)
+		p = p[32:]
+		i -= 32
+	}
+	if i > 16 {
+		seed = mix(read64(p)^smcSecret[0], read64(p[8:])^seed)
+	}
+
+	a := read64(d.tail[:8]) ^ d.length
+	b := read64(d.tail[8:16])
+
+	a ^= smcSecret[1]
+	b ^= seed
+	mum(&a, &b)
+	return mix(a^smcSecret[8], b^smcSecret[1]^d.length)
+}
+
+// Sum appends the big-endian encoding of Sum64 to b and returns the
+// resulting slice.
+func (d *Digest) Sum(b []byte) []byte {
+	s := d.Sum64()
+	return append(b,
+		byte(s>>56), byte(s>>48), byte(s>>40), byte(s>>32),
+		byte(s>>24), byte(s>>16), byte(s>>8), byte(s))
+}
@@ -0,0 +1,111 @@
+package smchash
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestDigestMatchesHashSeeded(t *testing.T) {
+	lengths := []int{0, 1, 3, 4, 7, 8, 15, 16, 17, 32, 63, 64, 65, 100,
+		127, 128, 129, 130, 140, 200, 255, 256, 257, 300, 512, 513, 1000}
+
+	for _, n := range lengths {
+		data := make([]byte, n)
+		for i := range data {
+			data[i] = byte(i * 7)
+		}
+
+		d := NewWithSeed(42)
+		if _, err := d.Write(data); err != nil {
+			t.Fatalf("length %d: Write returned error: %v", n, err)
+		}
+
+		got := d.Sum64()
+		want := HashSeeded(data, 42)
+		if got != want {
+			t.Errorf("length %d: Sum64() = 0x%x, want 0x%x", n, got, want)
+		}
+	}
+}
+
+func TestDigestIncrementalWrites(t *testing.T) {
+	data := make([]byte, 513)
+	for i := range data {
+		data[i] = byte(i * 13)
+	}
+	want := HashSeeded(data, 7)
+
+	for _, chunk := range []int{1, 3, 17, 64, 127, 128, 129, 300} {
+		d := NewWithSeed(7)
+		for off := 0; off < len(data); off += chunk {
+			end := off + chunk
+			if end > len(data) {
+				end = len(data)
+			}
+			d.Write(data[off:end])
+		}
+		if got := d.Sum64(); got != want {
+			t.Errorf("chunk size %d: Sum64() = 0x%x, want 0x%x", chunk, got, want)
+		}
+	}
+}
+
+func TestDigestWriteStringAndByte(t *testing.T) {
+	const s = "Hello, streaming World!"
+
+	byWrite := NewWithSeed(1)
+	byWrite.Write([]byte(s))
+
+	byPieces := NewWithSeed(1)
+	byPieces.WriteString(s[:5])
+	for _, c := range []byte(s[5:]) {
+		byPieces.WriteByte(c)
+	}
+
+	if byWrite.Sum64() != byPieces.Sum64() {
+		t.Error("WriteString/WriteByte produced a different hash than Write")
+	}
+	if got, want := byWrite.Sum64(), HashSeeded([]byte(s), 1); got != want {
+		t.Errorf("Sum64() = 0x%x, want 0x%x", got, want)
+	}
+}
+
+func TestDigestReset(t *testing.T) {
+	d := NewWithSeed(99)
+	d.Write([]byte("some data that spans more than one block boundary....."))
+	d.Write(make([]byte, 200))
+	d.Reset()
+
+	d.Write([]byte("Hello, World!"))
+	if got, want := d.Sum64(), HashSeeded([]byte("Hello, World!"), 99); got != want {
+		t.Errorf("Sum64() after Reset = 0x%x, want 0x%x", got, want)
+	}
+}
+
+func TestDigestSum(t *testing.T) {
+	d := NewWithSeed(5)
+	d.WriteString("Hello, World!")
+
+	got := d.Sum([]byte("prefix:"))
+	if !bytes.HasPrefix(got, []byte("prefix:")) {
+		t.Fatalf("Sum did not preserve prefix: %x", got)
+	}
+	if len(got) != len("prefix:")+d.Size() {
+		t.Fatalf("Sum returned %d bytes, want %d", len(got), len("prefix:")+d.Size())
+	}
+}
+
+func TestMakeSeedVaries(t *testing.T) {
+	if MakeSeed() == MakeSeed() {
+		t.Error("two calls to MakeSeed returned the same seed")
+	}
+}
+
+func TestNewAutoSeeds(t *testing.T) {
+	a, b := New(), New()
+	a.WriteString("same input")
+	b.WriteString("same input")
+	if a.Seed() == b.Seed() {
+		t.Error("two calls to New used the same seed")
+	}
+}
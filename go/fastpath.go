@@ -0,0 +1,39 @@
+package smchash
+
+import "unsafe"
+
+// HashString computes the smcHash of s with smcSecret[0] as the seed,
+// without copying s into a []byte first, analogous to the runtime's
+// string-keyed map hashing.
+func HashString(s string) uint64 {
+	if len(s) == 0 {
+		return Hash(nil)
+	}
+	return Hash(unsafe.Slice(unsafe.StringData(s), len(s)))
+}
+
+// Hash32 computes the smcHash of x's 4-byte little-endian encoding,
+// going straight to the 4-byte mix instead of dispatching on length,
+// analogous to the runtime's memhash32. It is bit-identical to
+// HashSeeded(le32(x), seed).
+func Hash32(x uint32, seed uint64) uint64 {
+	seed ^= mix(seed^smcSecret[0], smcSecret[1]^4)
+
+	a := uint64(x) ^ smcSecret[1]
+	b := uint64(x) ^ seed
+	mum(&a, &b)
+	return mix(a^smcSecret[8], b^smcSecret[1]^4)
+}
+
+// Hash64Value computes the smcHash of x's 8-byte little-endian
+// encoding, going straight to the 8-byte mix instead of dispatching on
+// length, analogous to the runtime's memhash64. It is bit-identical to
+// HashSeeded(le64(x), seed).
+func Hash64Value(x uint64, seed uint64) uint64 {
+	seed ^= mix(seed^smcSecret[0], smcSecret[1]^8)
+
+	a := x ^ smcSecret[1]
+	b := x ^ seed
+	mum(&a, &b)
+	return mix(a^smcSecret[8], b^smcSecret[1]^8)
+}
@@ -0,0 +1,42 @@
+package smchash
+
+import (
+	"encoding/binary"
+	"math/rand"
+	"testing"
+)
+
+func TestHash32Equality(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	var buf [4]byte
+	for i := 0; i < 10000; i++ {
+		x := rnd.Uint32()
+		seed := rnd.Uint64()
+		binary.LittleEndian.PutUint32(buf[:], x)
+		if got, want := Hash32(x, seed), HashSeeded(buf[:], seed); got != want {
+			t.Fatalf("Hash32(%d, %d) = 0x%x, want 0x%x", x, seed, got, want)
+		}
+	}
+}
+
+func TestHash64ValueEquality(t *testing.T) {
+	rnd := rand.New(rand.NewSource(2))
+	var buf [8]byte
+	for i := 0; i < 10000; i++ {
+		x := rnd.Uint64()
+		seed := rnd.Uint64()
+		binary.LittleEndian.PutUint64(buf[:], x)
+		if got, want := Hash64Value(x, seed), HashSeeded(buf[:], seed); got != want {
+			t.Fatalf("Hash64Value(%d, %d) = 0x%x, want 0x%x", x, seed, got, want)
+		}
+	}
+}
+
+func TestHashStringEquality(t *testing.T) {
+	cases := []string{"", "a", "Hello, World!", string(make([]byte, 200))}
+	for _, s := range cases {
+		if got, want := HashString(s), Hash([]byte(s)); got != want {
+			t.Errorf("HashString(%q) = 0x%x, want 0x%x", s, got, want)
+		}
+	}
+}
@@ -0,0 +1,17 @@
+//go:build amd64 && gc && !purego
+
+package smchash
+
+func init() {
+	if hasAVX2BMI2 {
+		bulkBlock = bulkBlockAVX2
+	}
+}
+
+// bulkBlockAVX2 is implemented in hash_amd64.s using MULX (BMI2) for the
+// lane multiplies and one 128-bit vector load per lane pair; the XORs
+// with the secret and lane state are scalar. It must be bit-identical to
+// bulkBlockGeneric.
+//
+//go:noescape
+func bulkBlockAVX2(p []byte, secret *[9]uint64, see *[8]uint64)
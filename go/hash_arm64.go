@@ -0,0 +1,16 @@
+//go:build arm64 && gc && !purego
+
+package smchash
+
+func init() {
+	// NEON (ASIMD) is mandatory baseline on arm64, so no runtime feature
+	// probe is needed here, unlike amd64's optional AVX2/BMI2.
+	bulkBlock = bulkBlockNEON
+}
+
+// bulkBlockNEON is implemented in hash_arm64.s using a NEON load to fetch
+// each lane's 128-bit input pair in one instruction, then UMULH/MUL for
+// the widening multiply. It must be bit-identical to bulkBlockGeneric.
+//
+//go:noescape
+func bulkBlockNEON(p []byte, secret *[9]uint64, see *[8]uint64)
@@ -85,25 +85,17 @@ func HashSeeded(data []byte, seed uint64) uint64 {
 
 	// Bulk: 8 lanes = 128 bytes = 2 cache lines
 	if length > 128 {
-		see1, see2, see3, see4 := seed, seed, seed, seed
-		see5, see6, see7 := seed, seed, seed
+		see := [8]uint64{seed, seed, seed, seed, seed, seed, seed, seed}
 
 		for i > 128 {
-			seed = mix(read64(p)^smcSecret[0], read64(p[8:])^seed)
-			see1 = mix(read64(p[16:])^smcSecret[1], read64(p[24:])^see1)
-			see2 = mix(read64(p[32:])^smcSecret[2], read64(p[40:])^see2)
-			see3 = mix(read64(p[48:])^smcSecret[3], read64(p[56:])^see3)
-			see4 = mix(read64(p[64:])^smcSecret[4], read64(p[72:])^see4)
-			see5 = mix(read64(p[80:])^smcSecret[5], read64(p[88:])^see5)
-			see6 = mix(read64(p[96:])^smcSecret[6], read64(p[104:])^see6)
-			see7 = mix(read64(p[112:])^smcSecret[7], read64(p[120:])^see7)
+			bulkBlock(p, &smcSecret, &see)
 			p = p[128:]
 			i -= 128
 		}
 
-		seed ^= see1 ^ see4 ^ see5
-		see2 ^= see3 ^ see6 ^ see7
-		seed ^= see2
+		seed = see[0] ^ see[1] ^ see[4] ^ see[5]
+		see[2] ^= see[3] ^ see[6] ^ see[7]
+		seed ^= see[2]
 	}
 
 	if i > 64 {
@@ -167,25 +159,17 @@ func HashSecret(data []byte, seed uint64, secret *[9]uint64) uint64 {
 	i := length
 
 	if length > 128 {
-		see1, see2, see3, see4 := seed, seed, seed, seed
-		see5, see6, see7 := seed, seed, seed
+		see := [8]uint64{seed, seed, seed, seed, seed, seed, seed, seed}
 
 		for i > 128 {
-			seed = mix(read64(p)^secret[0], read64(p[8:])^seed)
-			see1 = mix(read64(p[16:])^secret[1], read64(p[24:])^see1)
-			see2 = mix(read64(p[32:])^secret[2], read64(p[40:])^see2)
-			see3 = mix(read64(p[48:])^secret[3], read64(p[56:])^see3)
-			see4 = mix(read64(p[64:])^secret[4], read64(p[72:])^see4)
-			see5 = mix(read64(p[80:])^secret[5], read64(p[88:])^see5)
-			see6 = mix(read64(p[96:])^secret[6], read64(p[104:])^see6)
-			see7 = mix(read64(p[112:])^secret[7], read64(p[120:])^see7)
+			bulkBlock(p, secret, &see)
 			p = p[128:]
 			i -= 128
 		}
 
-		seed ^= see1 ^ see4 ^ see5
-		see2 ^= see3 ^ see6 ^ see7
-		seed ^= see2
+		seed = see[0] ^ see[1] ^ see[4] ^ see[5]
+		see[2] ^= see[3] ^ see[6] ^ see[7]
+		seed ^= see[2]
 	}
 
 	if i > 64 {
@@ -0,0 +1,306 @@
+//go:build !race
+
+// SMHasher3-style quality tests, ported in the spirit of
+// hash/maphash's smhasher_test.go, so the package doc's claim of
+// passing the SMHasher3 suite is checked on every run that is not built
+// with -race (the exhaustive cases are skipped under -short).
+package smchash
+
+import (
+	"encoding/binary"
+	"math/bits"
+	"math/rand"
+	"testing"
+)
+
+// collisions returns how many entries of hashes duplicate an earlier
+// entry.
+func collisions(hashes []uint64) int {
+	seen := make(map[uint64]struct{}, len(hashes))
+	n := 0
+	for _, h := range hashes {
+		if _, ok := seen[h]; ok {
+			n++
+			continue
+		}
+		seen[h] = struct{}{}
+	}
+	return n
+}
+
+// bitsDiff returns the number of bits that differ between a and b.
+func bitsDiff(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// Sanity: the hash of a key must not depend on the bytes surrounding it
+// in a larger buffer, nor on its alignment within that buffer.
+func TestSMHasherSanity(t *testing.T) {
+	const keyLen = 32
+	const pad = 16
+
+	key := make([]byte, keyLen)
+	for i := range key {
+		key[i] = byte(i*31 + 7)
+	}
+	want := Hash(key)
+
+	buf := make([]byte, pad+keyLen+pad)
+	for trial := 0; trial < 8; trial++ {
+		rnd := rand.New(rand.NewSource(int64(trial)))
+		rnd.Read(buf)
+		for offset := 0; offset <= 2*pad; offset++ {
+			copy(buf[offset:offset+keyLen], key)
+			if got := Hash(buf[offset : offset+keyLen]); got != want {
+				t.Fatalf("trial %d offset %d: got 0x%x, want 0x%x", trial, offset, got, want)
+			}
+		}
+	}
+}
+
+// AppendedZeros: Hash(k) must differ from Hash(k || 0^n) for every n,
+// i.e. length must actually participate in the hash.
+func TestSMHasherAppendedZeros(t *testing.T) {
+	base := Hash([]byte("the quick brown fox"))
+
+	padded := []byte("the quick brown fox")
+	for n := 1; n <= 256; n++ {
+		padded = append(padded, 0)
+		if Hash(padded) == base {
+			t.Fatalf("Hash(k) collided with Hash(k || 0^%d)", n)
+		}
+	}
+}
+
+// SmallKeys: every 1-3 byte key must hash without collision.
+func TestSMHasherSmallKeys(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping exhaustive small-key enumeration in -short mode")
+	}
+
+	for length := 1; length <= 3; length++ {
+		var hashes []uint64
+		key := make([]byte, length)
+
+		var enumerate func(pos int)
+		enumerate = func(pos int) {
+			if pos == length {
+				hashes = append(hashes, Hash(key))
+				return
+			}
+			for b := 0; b < 256; b++ {
+				key[pos] = byte(b)
+				enumerate(pos + 1)
+			}
+		}
+		enumerate(0)
+
+		if n := collisions(hashes); n != 0 {
+			t.Errorf("length %d: %d collisions among %d keys", length, n, len(hashes))
+		}
+	}
+}
+
+// Cyclic: keys built from a short repeating pattern must not collide.
+func TestSMHasherCyclic(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping cyclic torture test in -short mode")
+	}
+
+	const trials = 1000
+	const keyLen = 4096
+
+	// cycleLen starts at 3, not 1, because the cycle itself is only
+	// cycleLen random bytes: with fewer than 256^3 possible cycles a
+	// birthday collision among the cycles (not the hash) becomes likely
+	// at this trial count and would produce a false failure here.
+	for cycleLen := 3; cycleLen <= 8; cycleLen++ {
+		hashes := make([]uint64, trials)
+		rnd := rand.New(rand.NewSource(int64(cycleLen)))
+		cycle := make([]byte, cycleLen)
+		key := make([]byte, keyLen)
+
+		for i := 0; i < trials; i++ {
+			rnd.Read(cycle)
+			for j := range key {
+				key[j] = cycle[j%cycleLen]
+			}
+			hashes[i] = Hash(key)
+		}
+
+		if n := collisions(hashes); n != 0 {
+			t.Errorf("cycle length %d: %d collisions among %d keys", cycleLen, n, trials)
+		}
+	}
+}
+
+// Sparse: all keys of a fixed length with at most a few bits set must
+// not collide, exercising the near-zero region of the input space.
+func TestSMHasherSparse(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping sparse-keybit enumeration in -short mode")
+	}
+
+	const nbytes = 16
+	const maxBits = 3
+	bits := nbytes * 8
+
+	var hashes []uint64
+	var rec func(key []byte, start, remaining int)
+	rec = func(key []byte, start, remaining int) {
+		hashes = append(hashes, Hash(key))
+		if remaining == 0 {
+			return
+		}
+		for i := start; i < bits; i++ {
+			key[i/8] |= 1 << uint(i%8)
+			rec(key, i+1, remaining-1)
+			key[i/8] &^= 1 << uint(i%8)
+		}
+	}
+	rec(make([]byte, nbytes), 0, maxBits)
+
+	if n := collisions(hashes); n != 0 {
+		t.Errorf("%d collisions among %d sparse keys", n, len(hashes))
+	}
+}
+
+// Permutation: keys built from every sequence of 4 blocks (repeats
+// allowed) drawn from a small alphabet of "nasty" 64-bit blocks (0, 1,
+// all-ones-but-one-bit, the sign bit, alternating bits) must not collide.
+func TestSMHasherPermutation(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping permutation enumeration in -short mode")
+	}
+
+	alphabet := []uint64{0, 1, 0xfffffffffffffffe, 0x8000000000000000, 0x5555555555555555}
+	const blocks = 4
+
+	var hashes []uint64
+	key := make([]byte, 8*blocks)
+
+	var rec func(pos int)
+	rec = func(pos int) {
+		if pos == blocks {
+			hashes = append(hashes, Hash(key))
+			return
+		}
+		for _, v := range alphabet {
+			binary.LittleEndian.PutUint64(key[pos*8:], v)
+			rec(pos + 1)
+		}
+	}
+	rec(0)
+
+	if n := collisions(hashes); n != 0 {
+		t.Errorf("%d collisions among %d permuted keys", n, len(hashes))
+	}
+}
+
+// Avalanche: flipping any single input bit should flip each output bit
+// with probability close to 0.5.
+func TestSMHasherAvalanche(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping avalanche trial count in -short mode")
+	}
+
+	const trials = 20000
+	const keyLen = 16
+	const epsilon = 0.02
+	const inBits = keyLen * 8
+	const outBits = 64
+
+	rnd := rand.New(rand.NewSource(1))
+	flips := make([][outBits]int, inBits)
+	key := make([]byte, keyLen)
+
+	for t2 := 0; t2 < trials; t2++ {
+		rnd.Read(key)
+		base := Hash(key)
+		for i := 0; i < inBits; i++ {
+			key[i/8] ^= 1 << uint(i%8)
+			h := Hash(key)
+			key[i/8] ^= 1 << uint(i%8)
+
+			diff := base ^ h
+			for j := 0; j < outBits; j++ {
+				if diff&(1<<uint(j)) != 0 {
+					flips[i][j]++
+				}
+			}
+		}
+	}
+
+	for i := 0; i < inBits; i++ {
+		for j := 0; j < outBits; j++ {
+			p := float64(flips[i][j]) / float64(trials)
+			if p < 0.5-epsilon || p > 0.5+epsilon {
+				t.Errorf("input bit %d -> output bit %d flips with probability %.3f, want ~0.5", i, j, p)
+			}
+		}
+	}
+}
+
+// Windowed: slide a fixed 64-bit "nasty" pattern through a wider
+// all-zero key; every position must produce a distinct hash that
+// differs from the all-zero key's hash.
+func TestSMHasherWindowed(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping windowed sweep in -short mode")
+	}
+
+	const keyLen = 32
+	const pattern = uint64(0x8000000000000001)
+
+	baseHash := Hash(make([]byte, keyLen))
+
+	hashes := make([]uint64, 0, keyLen*8-63)
+	for bit := 0; bit <= keyLen*8-64; bit++ {
+		key := make([]byte, keyLen)
+		for b := 0; b < 64; b++ {
+			if pattern&(1<<uint(b)) != 0 {
+				idx := bit + b
+				key[idx/8] |= 1 << uint(idx%8)
+			}
+		}
+		h := Hash(key)
+		hashes = append(hashes, h)
+		if bitsDiff(h, baseHash) == 0 {
+			t.Errorf("windowed pattern at bit %d produced the all-zero key's hash", bit)
+		}
+	}
+
+	if n := collisions(hashes); n != 0 {
+		t.Errorf("%d collisions among %d windowed keys", n, len(hashes))
+	}
+}
+
+// Birthday: a rough collision-count estimate over a large population of
+// random keys, which should stay close to the ~n^2/2^65 expected for a
+// well-mixed 64-bit hash.
+func TestSMHasherBirthday(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping birthday collision estimate in -short mode")
+	}
+
+	const n = 2_000_000
+	rnd := rand.New(rand.NewSource(2))
+	seen := make(map[uint64]struct{}, n)
+	key := make([]byte, 20)
+
+	collisionCount := 0
+	for i := 0; i < n; i++ {
+		rnd.Read(key)
+		h := Hash(key)
+		if _, ok := seen[h]; ok {
+			collisionCount++
+		}
+		seen[h] = struct{}{}
+	}
+
+	const space = 18446744073709551616.0 // 2^64
+	expected := float64(n) * float64(n) / (2 * space)
+	if limit := expected*50 + 5; float64(collisionCount) > limit {
+		t.Errorf("saw %d collisions among %d hashes, expected about %.6f", collisionCount, n, expected)
+	}
+}
@@ -0,0 +1,93 @@
+package smchash
+
+import (
+	"encoding/binary"
+	"math/bits"
+	randv2 "math/rand/v2"
+)
+
+// SmcRand is a pseudo-random number generator built on the same mixing
+// step as Rand (passes BigCrush/PractRand), with a richer surface for
+// callers that want bounded draws, shuffling, or independent streams
+// for concurrent goroutines.
+type SmcRand struct {
+	state uint64
+}
+
+// NewSmcRand creates a SmcRand seeded with seed.
+func NewSmcRand(seed uint64) *SmcRand {
+	return &SmcRand{state: seed}
+}
+
+// Uint64 returns the next pseudo-random uint64 in the stream.
+func (r *SmcRand) Uint64() uint64 {
+	r.state += smcSecret[0]
+	return mix(r.state, r.state^smcSecret[1])
+}
+
+// Uint64n returns a pseudo-random number in [0, n) using Lemire's
+// unbiased bounded method. It panics if n == 0.
+func (r *SmcRand) Uint64n(n uint64) uint64 {
+	if n == 0 {
+		panic("smchash: invalid argument to Uint64n")
+	}
+	hi, lo := bits.Mul64(r.Uint64(), n)
+	if lo < n {
+		thresh := -n % n
+		for lo < thresh {
+			hi, lo = bits.Mul64(r.Uint64(), n)
+		}
+	}
+	return hi
+}
+
+// Float64 returns a pseudo-random number in [0.0, 1.0).
+func (r *SmcRand) Float64() float64 {
+	return float64(r.Uint64()>>11) / (1 << 53)
+}
+
+// Read fills p with pseudo-random bytes. It always returns len(p), nil.
+func (r *SmcRand) Read(p []byte) (int, error) {
+	n := len(p)
+	for len(p) >= 8 {
+		binary.LittleEndian.PutUint64(p, r.Uint64())
+		p = p[8:]
+	}
+	if len(p) > 0 {
+		var buf [8]byte
+		binary.LittleEndian.PutUint64(buf[:], r.Uint64())
+		copy(p, buf[:])
+	}
+	return n, nil
+}
+
+// Shuffle pseudo-randomly permutes n elements by calling swap, following
+// the same Fisher-Yates convention as math/rand's Shuffle. It panics if
+// n < 0.
+func (r *SmcRand) Shuffle(n int, swap func(i, j int)) {
+	if n < 0 {
+		panic("smchash: invalid argument to Shuffle")
+	}
+	for i := n - 1; i > 0; i-- {
+		j := int(r.Uint64n(uint64(i + 1)))
+		swap(i, j)
+	}
+}
+
+// Jump advances the stream by 2^32 draws in O(1), for splitting a
+// single seed into widely-separated streams.
+func (r *SmcRand) Jump() {
+	r.state += (uint64(1) << 32) * smcSecret[0]
+}
+
+// Split returns a new, independent SmcRand seeded from r, so a
+// goroutine can hand off a substream to a worker without the workers
+// contending on a shared generator.
+func (r *SmcRand) Split() *SmcRand {
+	seed := r.Uint64()
+	return NewSmcRand(mix(seed, seed^smcSecret[2]))
+}
+
+// SmcRand implements math/rand/v2's Source interface, so it can be used
+// directly with rand.New.
+var _ randv2.Source = (*SmcRand)(nil)
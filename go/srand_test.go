@@ -0,0 +1,75 @@
+package smchash
+
+import (
+	randv2 "math/rand/v2"
+	"testing"
+)
+
+func TestSmcRandUint64Distinct(t *testing.T) {
+	r := NewSmcRand(1)
+	a, b, c := r.Uint64(), r.Uint64(), r.Uint64()
+	if a == b || b == c || a == c {
+		t.Error("SmcRand.Uint64 produced duplicate values")
+	}
+}
+
+func TestSmcRandUint64nRange(t *testing.T) {
+	r := NewSmcRand(2)
+	const n = 37
+	for i := 0; i < 100000; i++ {
+		if v := r.Uint64n(n); v >= n {
+			t.Fatalf("Uint64n(%d) returned %d, out of range", n, v)
+		}
+	}
+}
+
+func TestSmcRandFloat64Range(t *testing.T) {
+	r := NewSmcRand(3)
+	for i := 0; i < 10000; i++ {
+		if v := r.Float64(); v < 0 || v >= 1 {
+			t.Fatalf("Float64() = %v, want [0,1)", v)
+		}
+	}
+}
+
+func TestSmcRandShuffle(t *testing.T) {
+	r := NewSmcRand(4)
+	data := make([]int, 20)
+	for i := range data {
+		data[i] = i
+	}
+	r.Shuffle(len(data), func(i, j int) { data[i], data[j] = data[j], data[i] })
+
+	seen := make(map[int]bool, len(data))
+	for _, v := range data {
+		seen[v] = true
+	}
+	if len(seen) != len(data) {
+		t.Error("Shuffle lost or duplicated an element")
+	}
+}
+
+func TestSmcRandSplitIndependent(t *testing.T) {
+	r := NewSmcRand(5)
+	a := r.Split()
+	b := r.Split()
+	if a.Uint64() == b.Uint64() {
+		t.Error("two Split streams produced the same first value")
+	}
+}
+
+func TestSmcRandJumpAdvances(t *testing.T) {
+	r1 := NewSmcRand(6)
+	r2 := NewSmcRand(6)
+	r2.Jump()
+	if r1.Uint64() == r2.Uint64() {
+		t.Error("Jump did not change the stream's output")
+	}
+}
+
+func TestSmcRandAsRandV2Source(t *testing.T) {
+	src := NewSmcRand(7)
+	rng := randv2.New(src)
+	_ = rng.Uint64()
+	_ = rng.IntN(100)
+}